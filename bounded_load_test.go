@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestBoundedLoadInvariant is a property test for the Mirrokni/Thorup/
+// Zadimoghaddam bounded-loads guarantee: after N inserts across K nodes,
+// no node should ever carry more than ceil((N/K)*loadFactor) keys.
+func TestBoundedLoadInvariant(t *testing.T) {
+	const (
+		numNodes   = 7
+		numKeys    = 2000
+		loadFactor = 1.25
+	)
+
+	ch := NewConsistentHash(150, loadFactor)
+	for i := 0; i < numNodes; i++ {
+		ch.AddNode(fmt.Sprintf("node%d", i))
+	}
+
+	for i := 0; i < numKeys; i++ {
+		if _, err := ch.GetNode(fmt.Sprintf("key-%d", i)); err != nil {
+			t.Fatalf("GetNode failed at key %d: %v", i, err)
+		}
+	}
+
+	bound := int(math.Ceil(float64(numKeys) / float64(numNodes) * loadFactor))
+
+	for name, node := range ch.nodes {
+		if node.load > bound {
+			t.Errorf("node %s load = %d, exceeds bound %d", name, node.load, bound)
+		}
+	}
+}
+
+// TestGetNodeNeverExhaustsCapacity checks the proof's claim that
+// n*C >= m+1 always holds by construction: GetNode should never return
+// ErrNoCapacity, even with a single node and loadFactor at its floor.
+func TestGetNodeNeverExhaustsCapacity(t *testing.T) {
+	ch := NewConsistentHash(150, 1.0)
+	ch.AddNode("server1")
+
+	for i := 0; i < 500; i++ {
+		if _, err := ch.GetNode(fmt.Sprintf("key-%d", i)); err != nil {
+			t.Fatalf("GetNode failed at key %d: %v", i, err)
+		}
+	}
+}
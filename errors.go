@@ -0,0 +1,15 @@
+package main
+
+import "errors"
+
+// ErrNoCapacity is returned when every node is at its bounded-load cap and
+// the ring genuinely has nowhere left to place a key. The Mirrokni/Thorup/
+// Zadimoghaddam proof guarantees this can't happen as long as loadFactor >
+// 1, so seeing it means loadFactor was misconfigured (<= 1) rather than
+// the ring being organically full.
+var ErrNoCapacity = errors.New("no node has spare capacity")
+
+// ErrNotEnoughNodes is returned by GetNodes/GetNodesExcluding when fewer
+// distinct physical nodes remain (after exclusions) than the requested
+// replica count.
+var ErrNotEnoughNodes = errors.New("not enough distinct nodes for the requested replica count")
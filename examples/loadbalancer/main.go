@@ -66,6 +66,21 @@ func (lb *LoadBalancer) RemoveBackend(id string) error {
 	return nil
 }
 
+// ReplicaSet returns the N backends a session's writes should replicate to,
+// in preference order, for failover when the primary is down.
+func (lb *LoadBalancer) ReplicaSet(sessionID string, n int) ([]*Backend, error) {
+	nodes, err := lb.ring.GetClosestN(sessionID, n)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]*Backend, 0, len(nodes))
+	for _, node := range nodes {
+		backends = append(backends, lb.backends[node.ID])
+	}
+	return backends, nil
+}
+
 func (lb *LoadBalancer) RouteRequest(req Request) (*Backend, error) {
 	// Use session ID for consistent routing
 	node, err := lb.ring.GetNodeWithBoundedLoad(req.SessionID)
@@ -227,6 +242,47 @@ func main() {
 	// Final statistics
 	lb.PrintStats()
 
+	// Demonstrate N=3 replication with failover
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("Replication (N=3) and Failover")
+	fmt.Println(strings.Repeat("=", 60))
+
+	replicatedSession := "session-replicated-1"
+	replicaCount := 3
+	if len(lb.backends) < replicaCount {
+		replicaCount = len(lb.backends)
+	}
+
+	replicas, err := lb.ReplicaSet(replicatedSession, replicaCount)
+	if err != nil {
+		log.Fatalf("Failed to compute replica set: %v", err)
+	}
+
+	fmt.Printf("\nReplica set for %s:\n", replicatedSession)
+	for i, backend := range replicas {
+		fmt.Printf("  replica %d -> %s (%s)\n", i+1, backend.ID, backend.Host)
+	}
+
+	lostBackend := replicas[0].ID
+	fmt.Printf("\n%s has failed! Removing from pool...\n", lostBackend)
+	if err := lb.RemoveBackend(lostBackend); err != nil {
+		log.Fatalf("Failed to remove backend: %v", err)
+	}
+
+	if len(lb.backends) < replicaCount {
+		replicaCount = len(lb.backends)
+	}
+
+	replicas, err = lb.ReplicaSet(replicatedSession, replicaCount)
+	if err != nil {
+		log.Fatalf("Failed to compute replica set after failover: %v", err)
+	}
+
+	fmt.Printf("\nReplica set for %s after failover:\n", replicatedSession)
+	for i, backend := range replicas {
+		fmt.Printf("  replica %d -> %s (%s)\n", i+1, backend.ID, backend.Host)
+	}
+
 	// Demonstrate session affinity
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Println("Session Affinity Test")
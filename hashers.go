@@ -0,0 +1,63 @@
+package main
+
+import (
+	"hash/crc32"
+	"hash/fnv"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/spaolacci/murmur3"
+)
+
+// Hasher computes a 64-bit hash for ring placement. Implementations must be
+// deterministic across processes so that independently-built rings agree
+// on ownership.
+type Hasher interface {
+	Sum64(key []byte) uint64
+}
+
+// FNV1aHasher is the original hash used by ConsistentHash, kept as the
+// default for backward compatibility. Its distribution on short keys like
+// "user:1001" is visibly uneven at low replica counts; prefer XXHasher or
+// Murmur3Hasher for new rings.
+type FNV1aHasher struct{}
+
+// Sum64 returns the FNV-1a 64-bit digest of key.
+func (FNV1aHasher) Sum64(key []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(key)
+	return h.Sum64()
+}
+
+// XXHasher is a Hasher backed by xxhash, the de-facto choice for consistent
+// hashing in ecosystems like go-redis and buraksezer/consistent. It is
+// roughly an order of magnitude faster than FNV-1a and distributes short
+// keys far more evenly.
+type XXHasher struct{}
+
+// Sum64 returns the xxhash64 digest of key.
+func (XXHasher) Sum64(key []byte) uint64 {
+	return xxhash.Sum64(key)
+}
+
+// Murmur3Hasher is a Hasher backed by Murmur3.
+type Murmur3Hasher struct{}
+
+// Sum64 returns the Murmur3 64-bit digest of key.
+func (Murmur3Hasher) Sum64(key []byte) uint64 {
+	return murmur3.Sum64(key)
+}
+
+// CRC32Hasher is a Hasher backed by CRC-32 (IEEE), widened to 64 bits.
+// Mainly useful for interop with systems that already standardize on
+// CRC-32 for partitioning.
+type CRC32Hasher struct{}
+
+// Sum64 returns the CRC-32 (IEEE) checksum of key as a uint64.
+func (CRC32Hasher) Sum64(key []byte) uint64 {
+	return uint64(crc32.ChecksumIEEE(key))
+}
+
+var _ Hasher = FNV1aHasher{}
+var _ Hasher = XXHasher{}
+var _ Hasher = Murmur3Hasher{}
+var _ Hasher = CRC32Hasher{}
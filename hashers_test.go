@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestHashersImplementInterface(t *testing.T) {
+	hashers := []Hasher{
+		FNV1aHasher{},
+		XXHasher{},
+		Murmur3Hasher{},
+		CRC32Hasher{},
+	}
+
+	for _, h := range hashers {
+		if got := h.Sum64([]byte("user:1001")); got == 0 {
+			t.Errorf("%T.Sum64 returned 0, which is suspiciously unlikely for a real digest", h)
+		}
+	}
+}
+
+func TestNewConsistentHashWithConfigUsesHasher(t *testing.T) {
+	ch := NewConsistentHashWithConfig(Config{
+		Replicas:   150,
+		LoadFactor: 1.25,
+		Hasher:     XXHasher{},
+	})
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+
+	node, err := ch.GetNode("user:1001")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node == "" {
+		t.Fatal("expected a non-empty node name")
+	}
+}
+
+func TestNewConsistentHashDefaultsToFNV1a(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	if _, ok := ch.hasher.(FNV1aHasher); !ok {
+		t.Fatalf("expected default hasher to be FNV1aHasher, got %T", ch.hasher)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestReleaseKeyUsesTrackedOwner(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+
+	node, err := ch.GetNode("user:1001")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+
+	owner, ok := ch.OwnerOf("user:1001")
+	if !ok || owner != node {
+		t.Fatalf("OwnerOf = (%q, %v), want (%q, true)", owner, ok, node)
+	}
+
+	if err := ch.ReleaseKey("user:1001"); err != nil {
+		t.Fatalf("ReleaseKey failed: %v", err)
+	}
+
+	if _, ok := ch.OwnerOf("user:1001"); ok {
+		t.Fatal("expected key to be untracked after ReleaseKey")
+	}
+
+	if ch.nodes[node].load != 0 {
+		t.Fatalf("expected load on %s to return to 0, got %d", node, ch.nodes[node].load)
+	}
+}
+
+func TestReleaseKeyUntrackedKeyErrors(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+
+	if err := ch.ReleaseKey("never-acquired"); err == nil {
+		t.Fatal("expected an error releasing a key that was never acquired")
+	}
+}
+
+func TestReassignMovesLoadAfterTopologyChange(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+
+	oldOwner, err := ch.GetNode("user:1001")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	newOwner, err := ch.Reassign("user:1001")
+	if err != nil {
+		t.Fatalf("Reassign failed: %v", err)
+	}
+
+	owner, ok := ch.OwnerOf("user:1001")
+	if !ok || owner != newOwner {
+		t.Fatalf("OwnerOf after Reassign = (%q, %v), want (%q, true)", owner, ok, newOwner)
+	}
+
+	if newOwner != oldOwner {
+		if ch.nodes[oldOwner].load != 0 {
+			t.Errorf("expected load on old owner %s to drop to 0, got %d", oldOwner, ch.nodes[oldOwner].load)
+		}
+	}
+	if ch.nodes[newOwner].load != 1 {
+		t.Errorf("expected load on new owner %s to be 1, got %d", newOwner, ch.nodes[newOwner].load)
+	}
+}
@@ -2,50 +2,123 @@ package main
 
 import (
 	"fmt"
-	"hash/fnv"
-	"slices"
-	"sort"
+	"math"
 	"sync"
 )
 
-// ConsistentHash implements consistent hashing with bounded loads
+// ConsistentHash implements consistent hashing with bounded loads. The ring
+// is backed by a skip list keyed by hash value, so adding or removing a
+// node's virtual points is O(V log N) instead of the O(N log N) full
+// re-sort a plain slice would need on every topology change.
 type ConsistentHash struct {
 	mu         sync.RWMutex
-	ring       []uint32
-	ringMap    map[uint32]string
+	ring       *skipList
 	nodes      map[string]*NodeInfo
+	keyOwners  map[string]string // key -> node name that GetNode assigned it to
 	replicas   int
 	loadFactor float64
+	hasher     Hasher
 }
 
 // NodeInfo stores information about each node
 type NodeInfo struct {
-	name string
-	load int // Current number of keys assigned to this node
+	name   string
+	load   int // Current number of keys assigned to this node
+	weight int // Relative capacity; scales the number of virtual nodes placed on the ring
 }
 
-// NewConsistentHash creates a new consistent hash ring
+// Config configures a ConsistentHash ring.
+type Config struct {
+	Replicas   int     // virtual nodes per physical node (default: 150)
+	LoadFactor float64 // bounded-load multiplier (default: 1.25)
+	Hasher     Hasher  // hash function used for ring placement (default: FNV1aHasher)
+}
+
+// DefaultConfig returns sensible defaults, matching the historical
+// NewConsistentHash(150, 1.25) behavior.
+func DefaultConfig() Config {
+	return Config{
+		Replicas:   150,
+		LoadFactor: 1.25,
+		Hasher:     FNV1aHasher{},
+	}
+}
+
+// NewConsistentHash creates a new consistent hash ring with the given
+// replica count and load factor, hashing with FNV-1a for backward
+// compatibility. Use NewConsistentHashWithConfig to pick a different
+// Hasher.
 func NewConsistentHash(replicas int, loadFactor float64) *ConsistentHash {
+	cfg := DefaultConfig()
+	cfg.Replicas = replicas
+	cfg.LoadFactor = loadFactor
+	return NewConsistentHashWithConfig(cfg)
+}
+
+// NewConsistentHashWithConfig creates a new consistent hash ring from cfg.
+// Zero-valued fields fall back to DefaultConfig's values.
+func NewConsistentHashWithConfig(cfg Config) *ConsistentHash {
+	if cfg.Replicas <= 0 {
+		cfg.Replicas = DefaultConfig().Replicas
+	}
+	if cfg.LoadFactor <= 0 {
+		cfg.LoadFactor = DefaultConfig().LoadFactor
+	}
+	if cfg.Hasher == nil {
+		cfg.Hasher = FNV1aHasher{}
+	}
+
 	return &ConsistentHash{
-		ringMap:    make(map[uint32]string),
+		ring:       newSkipList(),
 		nodes:      make(map[string]*NodeInfo),
-		replicas:   replicas,
-		loadFactor: loadFactor,
+		keyOwners:  make(map[string]string),
+		replicas:   cfg.Replicas,
+		loadFactor: cfg.LoadFactor,
+		hasher:     cfg.Hasher,
 	}
 }
 
-// hash generates a hash value for a given key
-func (ch *ConsistentHash) hash(key string) uint32 {
-	h := fnv.New32a()
-	h.Write([]byte(key))
-	return h.Sum32()
+// hash generates a hash value for a given key using the ring's configured
+// Hasher.
+func (ch *ConsistentHash) hash(key string) uint64 {
+	return ch.hasher.Sum64([]byte(key))
 }
 
-// AddNode adds a new node to the hash ring
+// AddNode adds a new node to the hash ring with the default weight of 1
 func (ch *ConsistentHash) AddNode(nodeName string) {
+	ch.AddNodeWithWeight(nodeName, 1)
+}
+
+// AddNodeWithWeight adds a new node with a relative weight, placing
+// weight*replicas virtual nodes on the ring instead of the default
+// replicas. This lets heterogeneous fleets (a bigger machine vs. a smaller
+// one) receive traffic proportional to capacity.
+func (ch *ConsistentHash) AddNodeWithWeight(nodeName string, weight int) {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
+	ch.addNodeLocked(nodeName, weight)
+}
+
+// AddNodes adds multiple nodes (each with the default weight of 1) under a
+// single lock acquisition, cheaper than calling AddNode in a loop when
+// onboarding a batch of nodes at once.
+func (ch *ConsistentHash) AddNodes(names ...string) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	for _, name := range names {
+		ch.addNodeLocked(name, 1)
+	}
+}
+
+// addNodeLocked adds a node and its virtual points to the ring. Callers
+// must hold ch.mu.
+func (ch *ConsistentHash) addNodeLocked(nodeName string, weight int) {
+	if weight <= 0 {
+		weight = 1
+	}
+
 	// Check if node already exists
 	if _, exists := ch.nodes[nodeName]; exists {
 		return
@@ -53,20 +126,53 @@ func (ch *ConsistentHash) AddNode(nodeName string) {
 
 	// Add node info
 	ch.nodes[nodeName] = &NodeInfo{
-		name: nodeName,
-		load: 0,
+		name:   nodeName,
+		load:   0,
+		weight: weight,
 	}
 
-	// Add virtual nodes (replicas) to the ring
-	for i := range ch.replicas {
+	// Add virtual nodes (replicas * weight) to the ring
+	for i := 0; i < ch.replicas*weight; i++ {
 		virtualKey := fmt.Sprintf("%s#%d", nodeName, i)
 		hashVal := ch.hash(virtualKey)
-		ch.ring = append(ch.ring, hashVal)
-		ch.ringMap[hashVal] = nodeName
+		ch.ring.Insert(hashVal, nodeName)
 	}
+}
+
+// UpdateWeight changes a node's weight, incrementally adding or removing
+// virtual node slots to match instead of rebuilding the whole ring.
+func (ch *ConsistentHash) UpdateWeight(nodeName string, weight int) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
 
-	// Sort the ring
-	slices.Sort(ch.ring)
+	node, exists := ch.nodes[nodeName]
+	if !exists {
+		return fmt.Errorf("node %s not found", nodeName)
+	}
+	if weight <= 0 {
+		return fmt.Errorf("weight must be positive, got %d", weight)
+	}
+
+	oldCount := ch.replicas * node.weight
+	newCount := ch.replicas * weight
+
+	switch {
+	case newCount > oldCount:
+		for i := oldCount; i < newCount; i++ {
+			virtualKey := fmt.Sprintf("%s#%d", nodeName, i)
+			hashVal := ch.hash(virtualKey)
+			ch.ring.Insert(hashVal, nodeName)
+		}
+	case newCount < oldCount:
+		for i := newCount; i < oldCount; i++ {
+			virtualKey := fmt.Sprintf("%s#%d", nodeName, i)
+			hashVal := ch.hash(virtualKey)
+			ch.ring.Delete(hashVal)
+		}
+	}
+
+	node.weight = weight
+	return nil
 }
 
 // RemoveNode removes a node from the hash ring
@@ -74,97 +180,261 @@ func (ch *ConsistentHash) RemoveNode(nodeName string) {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
-	if _, exists := ch.nodes[nodeName]; !exists {
+	ch.removeNodeLocked(nodeName)
+}
+
+// RemoveNodes removes multiple nodes under a single lock acquisition,
+// cheaper than calling RemoveNode in a loop when retiring a batch of nodes
+// at once.
+func (ch *ConsistentHash) RemoveNodes(names ...string) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	for _, name := range names {
+		ch.removeNodeLocked(name)
+	}
+}
+
+// removeNodeLocked removes a node and its virtual points from the ring.
+// Callers must hold ch.mu.
+func (ch *ConsistentHash) removeNodeLocked(nodeName string) {
+	node, exists := ch.nodes[nodeName]
+	if !exists {
 		return
 	}
 
 	// Remove virtual nodes from the ring
-	for i := 0; i < ch.replicas; i++ {
+	for i := 0; i < ch.replicas*node.weight; i++ {
 		virtualKey := fmt.Sprintf("%s#%d", nodeName, i)
 		hashVal := ch.hash(virtualKey)
-		delete(ch.ringMap, hashVal)
-
-		// Remove from ring slice
-		idx := ch.search(hashVal)
-		ch.ring = append(ch.ring[:idx], ch.ring[idx+1:]...)
+		ch.ring.Delete(hashVal)
 	}
 
 	delete(ch.nodes, nodeName)
 }
 
-// search finds the index of a hash value in the ring using binary search
-func (ch *ConsistentHash) search(hashVal uint32) int {
-	return sort.Search(len(ch.ring), func(i int) bool {
-		return ch.ring[i] >= hashVal
-	})
-}
-
 // GetNode returns the node responsible for a given key with bounded load
 func (ch *ConsistentHash) GetNode(key string) (string, error) {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
-	if len(ch.ring) == 0 {
+	if ch.ring.Len() == 0 {
 		return "", fmt.Errorf("no nodes available")
 	}
 
-	// Calculate average load and max allowed load
-	totalNodes := len(ch.nodes)
+	hashVal := ch.hash(key)
+	chosen, err := ch.chooseNodeLocked(hashVal)
+	if err != nil {
+		return "", err
+	}
+
+	ch.nodes[chosen].load++
+	ch.keyOwners[key] = chosen
+	return chosen, nil
+}
+
+// baseCapacityLocked computes ceil((m+1)/W), the average load per unit of
+// weight given m keys already placed across nodes with total weight W. This
+// is the normalizer nodeCapacityLocked scales by loadFactor and each node's
+// own weight; dividing by total weight rather than node count is what makes
+// a weight-2 node's fair share exactly 2x a weight-1 node's, regardless of
+// how many other nodes are in play. Callers must hold ch.mu.
+func (ch *ConsistentHash) baseCapacityLocked() int {
+	totalWeight := 0
 	totalLoad := 0
 	for _, node := range ch.nodes {
+		totalWeight += node.weight
 		totalLoad += node.load
 	}
+	if totalWeight == 0 {
+		return 1
+	}
 
-	avgLoad := float64(totalLoad+1) / float64(totalNodes) // +1 for the new key
-	maxLoad := int(avgLoad * ch.loadFactor)
+	// ceil((totalLoad+1) / totalWeight) via integer math.
+	return (totalLoad + totalWeight) / totalWeight
+}
 
-	// Hash the key
-	hashVal := ch.hash(key)
-	idx := ch.search(hashVal)
+// nodeCapacityLocked computes C = ceil(base * loadFactor * node.weight), the
+// Mirrokni/Thorup/Zadimoghaddam bounded-loads cap for node, so a 2x-weighted
+// node accepts 2x the bounded-load ceiling of a weight-1 node. Scaling up
+// only ever increases total capacity across the ring, so the
+// sum(C) >= m+1 termination guarantee still holds. Callers must hold ch.mu.
+func (ch *ConsistentHash) nodeCapacityLocked(base int, node *NodeInfo) int {
+	capacity := int(math.Ceil(float64(base) * ch.loadFactor * float64(node.weight)))
+	if capacity < 1 {
+		capacity = 1
+	}
+	return capacity
+}
 
-	// Search for a node with available capacity
-	// Start from the closest node and wrap around if necessary
-	for i := range ch.ring {
-		currIdx := (idx + i) % len(ch.ring)
-		nodeName := ch.ringMap[ch.ring[currIdx]]
+// chooseNodeLocked walks the ring clockwise from hashVal and returns the
+// first node whose load is still under its weighted bounded-load cap. The
+// proof guarantees this always succeeds when loadFactor > 1, since
+// n*C >= m+1 by construction; if it doesn't, loadFactor was configured <= 1
+// and ErrNoCapacity is returned rather than silently double-counting onto
+// the hashed node. Callers must hold ch.mu.
+func (ch *ConsistentHash) chooseNodeLocked(hashVal uint64) (string, error) {
+	base := ch.baseCapacityLocked()
+
+	var chosen string
+	ch.ring.Walk(hashVal, func(_ uint64, nodeName string) bool {
 		node := ch.nodes[nodeName]
-
-		// Check if this node is under the load limit
-		if node.load < maxLoad || maxLoad == 0 {
-			node.load++
-			return nodeName, nil
+		if node.load < ch.nodeCapacityLocked(base, node) {
+			chosen = nodeName
+			return false
 		}
+		return true
+	})
+
+	if chosen == "" {
+		return "", ErrNoCapacity
 	}
 
-	// If all nodes are at capacity, return the originally hashed node
-	nodeName := ch.ringMap[ch.ring[idx%len(ch.ring)]]
-	ch.nodes[nodeName].load++
-	return nodeName, nil
+	return chosen, nil
 }
 
-// ReleaseKey decrements the load for a key's assigned node
+// ReleaseKey decrements the load for a key's tracked owner, the node
+// GetNode actually assigned it to. This is the node the bounded-load walk
+// spilled the key onto, which is not necessarily the node currently at the
+// key's ring position — recomputing the hash here would drift the load
+// counters whenever GetNode had to spill, or whenever the topology changed
+// between acquire and release.
 func (ch *ConsistentHash) ReleaseKey(key string) error {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
-	if len(ch.ring) == 0 {
-		return fmt.Errorf("no nodes available")
+	nodeName, ok := ch.keyOwners[key]
+	if !ok {
+		return fmt.Errorf("key %s has no tracked owner", key)
+	}
+
+	if node, exists := ch.nodes[nodeName]; exists && node.load > 0 {
+		node.load--
+	}
+
+	delete(ch.keyOwners, key)
+	return nil
+}
+
+// OwnerOf returns the node a key is currently tracked as owned by, for
+// debugging and observability. The second return value is false if the
+// key has never been acquired via GetNode or has since been released.
+func (ch *ConsistentHash) OwnerOf(key string) (string, bool) {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	nodeName, ok := ch.keyOwners[key]
+	return nodeName, ok
+}
+
+// Reassign moves a tracked key to its current correct owner after a
+// topology change, atomically decrementing the old owner's load and
+// incrementing the new owner's. It is a no-op if its owner hasn't changed;
+// a key that isn't tracked yet is placed on its current owner, which takes
+// one unit of load and starts tracking the key, as GetNode would.
+func (ch *ConsistentHash) Reassign(key string) (string, error) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.ring.Len() == 0 {
+		return "", fmt.Errorf("no nodes available")
 	}
 
+	oldOwner, tracked := ch.keyOwners[key]
+
 	hashVal := ch.hash(key)
-	idx := ch.search(hashVal)
-	if idx >= len(ch.ring) {
-		idx = 0
+	newOwner, err := ch.chooseNodeLocked(hashVal)
+	if err != nil {
+		return "", err
 	}
 
-	nodeName := ch.ringMap[ch.ring[idx]]
-	node := ch.nodes[nodeName]
+	if tracked && oldOwner == newOwner {
+		return newOwner, nil
+	}
 
-	if node.load > 0 {
-		node.load--
+	if tracked {
+		if node, exists := ch.nodes[oldOwner]; exists && node.load > 0 {
+			node.load--
+		}
 	}
 
-	return nil
+	ch.nodes[newOwner].load++
+	ch.keyOwners[key] = newOwner
+	return newOwner, nil
+}
+
+// GetNodes returns n distinct physical nodes for key, for quorum
+// writes or read-from-any-replica patterns. It is equivalent to
+// GetNodesExcluding(key, n, nil).
+func (ch *ConsistentHash) GetNodes(key string, n int) ([]string, error) {
+	return ch.GetNodesExcluding(key, n, nil)
+}
+
+// GetNodesExcluding returns n distinct physical nodes for key, skipping
+// any name in exclude, for failover when a subset of nodes is known
+// unhealthy. If fewer than n nodes remain after exclusions it returns
+// ErrNotEnoughNodes rather than a shorter list. Nodes are found by walking the ring clockwise from the
+// hashed position, skipping virtual points of physicals already chosen.
+// The bounded-load cap is honored on a first pass; if fewer than n nodes
+// remain under the cap, a second pass fills the rest ignoring it, since
+// quorum/failover placement must not fail just because every remaining
+// replica happens to be momentarily full.
+func (ch *ConsistentHash) GetNodesExcluding(key string, n int, exclude []string) ([]string, error) {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	if n <= 0 {
+		return nil, nil
+	}
+	if ch.ring.Len() == 0 {
+		return nil, fmt.Errorf("no nodes available")
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	available := 0
+	for name := range ch.nodes {
+		if !excluded[name] {
+			available++
+		}
+	}
+	if n > available {
+		return nil, ErrNotEnoughNodes
+	}
+
+	base := ch.baseCapacityLocked()
+	hashVal := ch.hash(key)
+
+	seen := make(map[string]bool, n)
+	result := make([]string, 0, n)
+
+	collect := func(honorCapacity bool) {
+		ch.ring.Walk(hashVal, func(_ uint64, nodeName string) bool {
+			if len(result) >= n {
+				return false
+			}
+			if seen[nodeName] || excluded[nodeName] {
+				return true
+			}
+			node := ch.nodes[nodeName]
+			if honorCapacity && node.load >= ch.nodeCapacityLocked(base, node) {
+				return true
+			}
+			seen[nodeName] = true
+			result = append(result, nodeName)
+			return true
+		})
+	}
+
+	collect(true)
+	if len(result) < n {
+		collect(false)
+	}
+
+	return result, nil
 }
 
 // GetStats returns load statistics for all nodes
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConsistentHashGetNode(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	node, err := ch.GetNode("test-key")
+	if err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if node == "" {
+		t.Fatal("Expected a non-empty node name")
+	}
+}
+
+func TestConsistentHashRemoveNode(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+
+	ch.RemoveNode("server1")
+
+	for i := 0; i < 50; i++ {
+		node, err := ch.GetNode(fmt.Sprintf("key-%d", i))
+		if err != nil {
+			t.Fatalf("Failed to get node: %v", err)
+		}
+		if node == "server1" {
+			t.Errorf("Key assigned to removed node server1")
+		}
+	}
+}
+
+func TestAddNodesRemoveNodesRingLength(t *testing.T) {
+	const replicas = 150
+	ch := NewConsistentHash(replicas, 1.25)
+
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%d", i)
+	}
+	ch.AddNodes(names...)
+
+	if got, want := ch.ring.Len(), 100*replicas; got != want {
+		t.Fatalf("ring length after AddNodes = %d, want %d", got, want)
+	}
+
+	ch.RemoveNodes(names[:50]...)
+
+	if got, want := ch.ring.Len(), 50*replicas; got != want {
+		t.Fatalf("ring length after RemoveNodes = %d, want %d", got, want)
+	}
+
+	for _, removed := range names[:50] {
+		if _, exists := ch.nodes[removed]; exists {
+			t.Errorf("node %s still present in ch.nodes after RemoveNodes", removed)
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		node, err := ch.GetNode(fmt.Sprintf("key-%d", i))
+		if err != nil {
+			t.Fatalf("GetNode failed: %v", err)
+		}
+		for _, removed := range names[:50] {
+			if node == removed {
+				t.Errorf("key routed to removed node %s, stale ring entry", removed)
+			}
+		}
+	}
+}
+
+// BenchmarkChurn measures lookup cost while a node is added or removed
+// every K lookups, the workload the skip-list-backed ring targets: frequent
+// topology changes without paying for a full ring re-sort each time.
+func BenchmarkChurn(b *testing.B) {
+	const churnEvery = 100
+
+	ch := NewConsistentHash(150, 1.25)
+	for i := 0; i < 10; i++ {
+		ch.AddNode(fmt.Sprintf("node%d", i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%churnEvery == 0 {
+			name := fmt.Sprintf("churn-node-%d", i)
+			ch.AddNode(name)
+			ch.RemoveNode(name)
+		}
+		ch.GetNode(fmt.Sprintf("key-%d", i))
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultPartitionCount is used when NewPartitioner is given a
+// non-positive partition count.
+const defaultPartitionCount = 271
+
+// Partitioner pre-computes a fixed partition table on top of a
+// ConsistentHash ring. Partition ownership is resolved once, honoring the
+// ring's bounded-load constraint at assignment time rather than on every
+// lookup, so GetPartitionOwner becomes a pure hash + array index with no
+// locking cost beyond a table read.
+type Partitioner struct {
+	mu             sync.RWMutex
+	ch             *ConsistentHash
+	partitionCount int
+	table          []string // partition id -> owning node name
+}
+
+// NewPartitioner creates a Partitioner with partitionCount partitions
+// backed by ch, and builds the initial partition table. partitionCount
+// defaults to 271 if not positive.
+func NewPartitioner(ch *ConsistentHash, partitionCount int) *Partitioner {
+	if partitionCount <= 0 {
+		partitionCount = defaultPartitionCount
+	}
+
+	p := &Partitioner{
+		ch:             ch,
+		partitionCount: partitionCount,
+		table:          make([]string, partitionCount),
+	}
+	p.rebuild()
+	return p
+}
+
+// GetPartitionID returns the partition a key falls into, always in
+// [0, partitionCount). ch.hash returns a uint64 spanning the full range,
+// so the modulus is taken before narrowing to int to avoid producing a
+// negative partition id on platforms where int is 64-bit.
+func (p *Partitioner) GetPartitionID(key string) int {
+	return int(p.ch.hash(key) % uint64(p.partitionCount))
+}
+
+// GetPartitionOwner returns the node currently assigned to pid, or "" if
+// pid is out of range or no node is assigned yet.
+func (p *Partitioner) GetPartitionOwner(pid int) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if pid < 0 || pid >= len(p.table) {
+		return ""
+	}
+	return p.table[pid]
+}
+
+// GetClosestN returns up to n distinct node names for key's partition,
+// found by walking the ring clockwise from the partition's assigned
+// position, for replica placement.
+func (p *Partitioner) GetClosestN(key string, n int) []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if n <= 0 {
+		return nil
+	}
+
+	pid := p.GetPartitionID(key)
+	hashVal := p.ch.hash(fmt.Sprintf("partition-id:%d", pid))
+
+	seen := make(map[string]bool, n)
+	result := make([]string, 0, n)
+	p.ch.ring.Walk(hashVal, func(_ uint64, nodeName string) bool {
+		if seen[nodeName] {
+			return true
+		}
+		seen[nodeName] = true
+		result = append(result, nodeName)
+		return len(result) < n
+	})
+
+	return result
+}
+
+// AddNode adds a node to the underlying ring and rebuilds the partition
+// table, returning the ids of the partitions whose owner changed so
+// callers can drive data migration for just those partitions.
+func (p *Partitioner) AddNode(nodeName string) []int {
+	p.ch.AddNode(nodeName)
+	return p.rebuild()
+}
+
+// RemoveNode removes a node from the underlying ring and rebuilds the
+// partition table, returning the ids of the partitions whose owner
+// changed.
+func (p *Partitioner) RemoveNode(nodeName string) []int {
+	p.ch.RemoveNode(nodeName)
+	return p.rebuild()
+}
+
+// rebuild reassigns every partition to an owner, in partition id order,
+// honoring the ring's bounded-load constraint, and reports which
+// partitions moved relative to the previous table.
+func (p *Partitioner) rebuild() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ch := p.ch
+	ch.mu.RLock()
+	numNodes := len(ch.nodes)
+	ch.mu.RUnlock()
+
+	newTable := make([]string, p.partitionCount)
+
+	if numNodes > 0 {
+		avgLoad := float64(p.partitionCount) / float64(numNodes)
+		maxLoad := int(avgLoad*ch.loadFactor + 0.5)
+		if maxLoad == 0 {
+			maxLoad = 1
+		}
+
+		load := make(map[string]int, numNodes)
+
+		for pid := 0; pid < p.partitionCount; pid++ {
+			hashVal := ch.hash(fmt.Sprintf("partition-id:%d", pid))
+
+			owner := ""
+			ch.ring.Walk(hashVal, func(_ uint64, nodeName string) bool {
+				if load[nodeName] < maxLoad {
+					owner = nodeName
+					return false
+				}
+				return true
+			})
+			if owner == "" {
+				if _, nodeName, ok := ch.ring.Ceiling(hashVal); ok {
+					owner = nodeName
+				}
+			}
+
+			load[owner]++
+			newTable[pid] = owner
+		}
+	}
+
+	moved := movedPartitions(p.table, newTable)
+	p.table = newTable
+	return moved
+}
+
+// movedPartitions returns the ids where oldTable and newTable disagree.
+func movedPartitions(oldTable, newTable []string) []int {
+	var moved []int
+	for i, owner := range newTable {
+		if oldTable[i] != owner {
+			moved = append(moved, i)
+		}
+	}
+	return moved
+}
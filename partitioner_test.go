@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPartitionerGetPartitionOwner(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	p := NewPartitioner(ch, 271)
+
+	for pid := 0; pid < 271; pid++ {
+		owner := p.GetPartitionOwner(pid)
+		if owner == "" {
+			t.Fatalf("partition %d has no owner", pid)
+		}
+	}
+}
+
+func TestPartitionerGetPartitionIDStable(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+
+	p := NewPartitioner(ch, 271)
+
+	first := p.GetPartitionID("user:1001")
+	second := p.GetPartitionID("user:1001")
+	if first != second {
+		t.Fatalf("GetPartitionID not stable: %d != %d", first, second)
+	}
+}
+
+func TestPartitionerRebalanceReportsMinimalMoves(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	p := NewPartitioner(ch, 271)
+
+	moved := p.AddNode("server4")
+	if len(moved) == 0 {
+		t.Fatal("expected at least one partition to move after adding a node")
+	}
+	if len(moved) == 271 {
+		t.Fatal("expected only a subset of partitions to move, not all of them")
+	}
+
+	gotServer4 := false
+	for _, pid := range moved {
+		if p.GetPartitionOwner(pid) == "server4" {
+			gotServer4 = true
+			break
+		}
+	}
+	if !gotServer4 {
+		t.Error("expected server4 to pick up at least one moved partition")
+	}
+}
+
+func TestPartitionerGetPartitionIDInRange(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	p := NewPartitioner(ch, 271)
+
+	for i := 0; i < 10000; i++ {
+		pid := p.GetPartitionID(fmt.Sprintf("key-%d", i))
+		if pid < 0 || pid >= 271 {
+			t.Fatalf("GetPartitionID returned %d, want [0, 271)", pid)
+		}
+		if owner := p.GetPartitionOwner(pid); owner == "" {
+			t.Fatalf("GetPartitionOwner(%d) returned \"\" for key-%d", pid, i)
+		}
+	}
+}
+
+func TestPartitionerGetClosestN(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	p := NewPartitioner(ch, 271)
+
+	nodes := p.GetClosestN("user:1001", 2)
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+	}
+	if nodes[0] == nodes[1] {
+		t.Fatalf("expected distinct nodes, got %v", nodes)
+	}
+}
@@ -0,0 +1,23 @@
+package consistenthash
+
+import (
+	"errors"
+
+	"github.com/smol-go/smol-hash/pkg/smolhash"
+)
+
+// Sentinel errors, re-exported from smolhash so callers can use errors.Is
+// regardless of which backend (HashRing, PartitionRing, RendezvousHash)
+// they're using.
+var (
+	ErrNoNodes        = smolhash.ErrNoServers
+	ErrNodeNotFound   = smolhash.ErrServerNotFound
+	ErrNodeExists     = smolhash.ErrServerExists
+	ErrAllNodesFull   = smolhash.ErrAllServersOverloaded
+	ErrKeyNotFound    = smolhash.ErrKeyNotFound
+	ErrNotEnoughNodes = smolhash.ErrNotEnoughNodes
+)
+
+// ErrNoLocalNode is returned by GetLocal when no node matching the caller's
+// locality is found within the configured ring-walk budget.
+var ErrNoLocalNode = errors.New("no local node found within ring-walk budget")
@@ -1,29 +1,35 @@
 package consistenthash
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
 	"fmt"
 	"sort"
 	"sync"
+
+	"github.com/smol-go/smol-hash/pkg/smolhash"
 )
 
 // HashRing represents the consistent hash ring
 type HashRing struct {
-	mu            sync.RWMutex
-	nodes         map[string]*Node  // nodeID -> Node
-	ring          []uint32          // sorted hash values
-	ringMap       map[uint32]string // hash -> nodeID
-	virtualNodes  int               // number of virtual nodes per physical node
-	totalKeys     int               // total number of keys assigned
-	loadFactor    float64           // load factor for bounded loads
-	keyAssignment map[string]string // key -> nodeID mapping (for tracking)
+	mu             sync.RWMutex
+	nodes          map[string]*Node  // nodeID -> Node
+	ring           []uint64          // sorted hash values
+	ringMap        map[uint64]string // hash -> nodeID
+	virtualNodes   int               // number of virtual nodes per physical node
+	totalKeys      int               // total number of keys assigned
+	loadFactor     float64           // load factor for bounded loads
+	keyAssignment  map[string]string // key -> nodeID mapping (for tracking)
+	hasher         smolhash.HashFunc // hash function used to place keys and virtual nodes on the ring
+	localityKeys   []string          // Node.Metadata keys used for diversity constraints, in priority order
+	localityBudget int               // max ring positions GetLocal will walk before giving up (0 = walk the whole ring)
 }
 
 // Config holds configuration for the hash ring
 type Config struct {
-	VirtualNodes int     // Number of virtual nodes per physical node (default: 150)
-	LoadFactor   float64 // Load factor for bounded loads (default: 1.25)
+	VirtualNodes       int               // Number of virtual nodes per physical node (default: 150)
+	LoadFactor         float64           // Load factor for bounded loads (default: 1.25)
+	Hasher             smolhash.HashFunc // Hash function for ring placement (default: SHA256Hasher, for backward compatibility)
+	LocalityKeys       []string          // Node.Metadata keys (e.g. "zone", "rack") used to diversify GetClosestNWithLocality, in priority order
+	LocalityWalkBudget int               // Max ring positions GetLocal will walk before returning ErrNoLocalNode (default: walk the whole ring)
 }
 
 // DefaultConfig returns sensible defaults
@@ -31,6 +37,7 @@ func DefaultConfig() Config {
 	return Config{
 		VirtualNodes: 150,
 		LoadFactor:   1.25,
+		Hasher:       SHA256Hasher{},
 	}
 }
 
@@ -42,23 +49,26 @@ func NewHashRing(config Config) *HashRing {
 	if config.LoadFactor <= 0 {
 		config.LoadFactor = 1.25
 	}
+	if config.Hasher == nil {
+		config.Hasher = SHA256Hasher{}
+	}
 
 	return &HashRing{
-		nodes:         make(map[string]*Node),
-		ring:          make([]uint32, 0),
-		ringMap:       make(map[uint32]string),
-		virtualNodes:  config.VirtualNodes,
-		loadFactor:    config.LoadFactor,
-		keyAssignment: make(map[string]string),
+		nodes:          make(map[string]*Node),
+		ring:           make([]uint64, 0),
+		ringMap:        make(map[uint64]string),
+		virtualNodes:   config.VirtualNodes,
+		loadFactor:     config.LoadFactor,
+		keyAssignment:  make(map[string]string),
+		hasher:         config.Hasher,
+		localityKeys:   config.LocalityKeys,
+		localityBudget: config.LocalityWalkBudget,
 	}
 }
 
-// hash generates a 32-bit hash using SHA-256
-func (h *HashRing) hash(key string) uint32 {
-	hasher := sha256.New()
-	hasher.Write([]byte(key))
-	hashBytes := hasher.Sum(nil)
-	return binary.BigEndian.Uint32(hashBytes[:4])
+// hash generates a 64-bit hash using the configured Hasher
+func (h *HashRing) hash(key string) uint64 {
+	return h.hasher.Sum64([]byte(key))
 }
 
 // AddNode adds a physical node to the hash ring
@@ -67,13 +77,16 @@ func (h *HashRing) AddNode(node *Node) error {
 	defer h.mu.Unlock()
 
 	if _, exists := h.nodes[node.ID]; exists {
-		return fmt.Errorf("node %s already exists", node.ID)
+		return fmt.Errorf("node %s already exists: %w", node.ID, ErrNodeExists)
 	}
 
+	if node.Weight <= 0 {
+		node.Weight = 1
+	}
 	h.nodes[node.ID] = node
 
-	// Add virtual nodes to the ring
-	for i := 0; i < h.virtualNodes; i++ {
+	// Add virtual nodes to the ring, scaled by the node's weight
+	for i := 0; i < h.virtualNodes*node.Weight; i++ {
 		virtualKey := fmt.Sprintf("%s#%d", node.ID, i)
 		hashVal := h.hash(virtualKey)
 		h.ring = append(h.ring, hashVal)
@@ -101,11 +114,11 @@ func (h *HashRing) RemoveNode(nodeID string) error {
 
 	node, exists := h.nodes[nodeID]
 	if !exists {
-		return fmt.Errorf("node %s not found", nodeID)
+		return fmt.Errorf("node %s not found: %w", nodeID, ErrNodeNotFound)
 	}
 
 	// Remove virtual nodes from the ring
-	newRing := make([]uint32, 0, len(h.ring))
+	newRing := make([]uint64, 0, len(h.ring))
 	for _, hashVal := range h.ring {
 		if h.ringMap[hashVal] != nodeID {
 			newRing = append(newRing, hashVal)
@@ -129,28 +142,232 @@ func (h *HashRing) RemoveNode(nodeID string) error {
 	return nil
 }
 
+// UpdateWeight changes a node's weight, incrementally adding or removing
+// virtual node slots on the ring to match, and triggers a rebalance.
+func (h *HashRing) UpdateWeight(nodeID string, newWeight int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	node, exists := h.nodes[nodeID]
+	if !exists {
+		return fmt.Errorf("node %s not found: %w", nodeID, ErrNodeNotFound)
+	}
+	if newWeight <= 0 {
+		return fmt.Errorf("weight must be positive, got %d", newWeight)
+	}
+
+	oldCount := h.virtualNodes * node.Weight
+	newCount := h.virtualNodes * newWeight
+
+	switch {
+	case newCount > oldCount:
+		for i := oldCount; i < newCount; i++ {
+			virtualKey := fmt.Sprintf("%s#%d", node.ID, i)
+			hashVal := h.hash(virtualKey)
+			h.ring = append(h.ring, hashVal)
+			h.ringMap[hashVal] = node.ID
+		}
+		sort.Slice(h.ring, func(i, j int) bool {
+			return h.ring[i] < h.ring[j]
+		})
+	case newCount < oldCount:
+		for i := newCount; i < oldCount; i++ {
+			virtualKey := fmt.Sprintf("%s#%d", node.ID, i)
+			hashVal := h.hash(virtualKey)
+			delete(h.ringMap, hashVal)
+		}
+		newRing := make([]uint64, 0, len(h.ring))
+		for _, hashVal := range h.ring {
+			if _, stillPresent := h.ringMap[hashVal]; stillPresent {
+				newRing = append(newRing, hashVal)
+			}
+		}
+		h.ring = newRing
+	}
+
+	node.Weight = newWeight
+
+	h.updateMaxLoads()
+	h.rebalanceKeys()
+
+	return nil
+}
+
 // GetNode returns the node responsible for a given key
 func (h *HashRing) GetNode(key string) (*Node, error) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	if len(h.nodes) == 0 {
-		return nil, fmt.Errorf("no nodes available")
+		return nil, ErrNoNodes
 	}
 
 	hashVal := h.hash(key)
 	return h.getNodeForHash(hashVal), nil
 }
 
+// GetClosestN returns the first n distinct physical nodes encountered
+// walking the ring clockwise from hash(key), skipping duplicate hits on
+// virtual nodes of nodes already selected. This is the standard primitive
+// for replication (write to N replicas), read repair, and hinted handoff on
+// top of a consistent hash ring.
+func (h *HashRing) GetClosestN(key string, n int) ([]*Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if n > len(h.nodes) {
+		return nil, ErrNotEnoughNodes
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	hashVal := h.hash(key)
+	startIdx := h.search(hashVal)
+	ringLen := len(h.ring)
+
+	seen := make(map[string]bool, n)
+	result := make([]*Node, 0, n)
+
+	for i := 0; i < ringLen && len(result) < n; i++ {
+		idx := (startIdx + i) % ringLen
+		nodeID := h.ringMap[h.ring[idx]]
+		if seen[nodeID] {
+			continue
+		}
+		seen[nodeID] = true
+		result = append(result, h.nodes[nodeID])
+	}
+
+	return result, nil
+}
+
+// GetClosestNWithLocality returns the first n distinct physical nodes for
+// key, like GetClosestN, but enforces the classic replica-diversity
+// constraint: no two of the first n owners share the same value for
+// LocalityKeys[0] (e.g. "zone"), falling back to LocalityKeys[1] (e.g.
+// "rack"), and so on, until n nodes can actually be satisfied. Nodes with no
+// value set for a locality key are treated as always compatible with it.
+func (h *HashRing) GetClosestNWithLocality(key string, n int) ([]*Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if n > len(h.nodes) {
+		return nil, ErrNotEnoughNodes
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	hashVal := h.hash(key)
+	startIdx := h.search(hashVal)
+	ringLen := len(h.ring)
+
+	// Try enforcing diversity on each locality key in priority order; if a
+	// key doesn't have enough distinct values to fill all n slots, fall back
+	// to the next key. The final attempt (constraintIdx == len(localityKeys))
+	// applies no constraint at all, and is guaranteed to succeed.
+	for constraintIdx := 0; constraintIdx <= len(h.localityKeys); constraintIdx++ {
+		seen := make(map[string]bool, n)
+		usedValues := make(map[string]bool, n)
+		result := make([]*Node, 0, n)
+
+		for i := 0; i < ringLen && len(result) < n; i++ {
+			idx := (startIdx + i) % ringLen
+			nodeID := h.ringMap[h.ring[idx]]
+			if seen[nodeID] {
+				continue
+			}
+			node := h.nodes[nodeID]
+
+			if constraintIdx < len(h.localityKeys) {
+				val, ok := node.Metadata[h.localityKeys[constraintIdx]]
+				if ok && val != "" {
+					if usedValues[val] {
+						continue
+					}
+					usedValues[val] = true
+				}
+			}
+
+			seen[nodeID] = true
+			result = append(result, node)
+		}
+
+		if len(result) == n {
+			return result, nil
+		}
+	}
+
+	// Unreachable: the unconstrained pass always fills n slots since n <= len(h.nodes).
+	return nil, ErrNotEnoughNodes
+}
+
+// GetLocal returns the closest ring owner for key whose metadata matches
+// every key/value in localityValues, for reading from the nearest replica.
+// It walks at most LocalityWalkBudget ring positions (or the whole ring if
+// unset) before giving up, so lookups stay bounded even on large rings.
+func (h *HashRing) GetLocal(key string, localityValues map[string]string) (*Node, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.nodes) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	hashVal := h.hash(key)
+	startIdx := h.search(hashVal)
+	ringLen := len(h.ring)
+
+	budget := h.localityBudget
+	if budget <= 0 || budget > ringLen {
+		budget = ringLen
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < budget; i++ {
+		idx := (startIdx + i) % ringLen
+		nodeID := h.ringMap[h.ring[idx]]
+		if seen[nodeID] {
+			continue
+		}
+		seen[nodeID] = true
+
+		node := h.nodes[nodeID]
+		if nodeMatchesLocality(node, localityValues) {
+			return node, nil
+		}
+	}
+
+	return nil, ErrNoLocalNode
+}
+
+// nodeMatchesLocality reports whether node's metadata satisfies every
+// key/value pair in localityValues.
+func nodeMatchesLocality(node *Node, localityValues map[string]string) bool {
+	for k, v := range localityValues {
+		if node.Metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // GetNodeWithBoundedLoad returns a node for the key respecting bounded loads
 func (h *HashRing) GetNodeWithBoundedLoad(key string) (*Node, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	if len(h.nodes) == 0 {
-		return nil, fmt.Errorf("no nodes available")
+		return nil, ErrNoNodes
 	}
 
+	// MaxLoad is only a function of totalKeys and each node's weight, so
+	// recompute it here rather than relying on the last AddNode/RemoveNode
+	// call: otherwise the ceiling stays frozen at whatever it was when the
+	// ring was last resized and never tracks load as keys are assigned.
+	h.updateMaxLoads()
+
 	hashVal := h.hash(key)
 
 	// Try to find a node that can accept the key
@@ -197,7 +414,7 @@ func (h *HashRing) RemoveKey(key string) error {
 
 	nodeID, exists := h.keyAssignment[key]
 	if !exists {
-		return fmt.Errorf("key %s not found", key)
+		return fmt.Errorf("key %s not found: %w", key, ErrKeyNotFound)
 	}
 
 	if node, ok := h.nodes[nodeID]; ok {
@@ -206,19 +423,20 @@ func (h *HashRing) RemoveKey(key string) error {
 
 	delete(h.keyAssignment, key)
 	h.totalKeys--
+	h.updateMaxLoads()
 
 	return nil
 }
 
 // getNodeForHash finds the node for a given hash value (without lock)
-func (h *HashRing) getNodeForHash(hashVal uint32) *Node {
+func (h *HashRing) getNodeForHash(hashVal uint64) *Node {
 	idx := h.search(hashVal)
 	nodeID := h.ringMap[h.ring[idx]]
 	return h.nodes[nodeID]
 }
 
 // search performs binary search to find the first node >= hashVal
-func (h *HashRing) search(hashVal uint32) int {
+func (h *HashRing) search(hashVal uint64) int {
 	idx := sort.Search(len(h.ring), func(i int) bool {
 		return h.ring[i] >= hashVal
 	})
@@ -237,17 +455,24 @@ func (h *HashRing) updateMaxLoads() {
 		return
 	}
 
-	// Calculate average load per node
-	avgLoad := float64(h.totalKeys) / float64(len(h.nodes))
-
-	// Max load = ceil(avgLoad * loadFactor)
-	maxLoad := int(avgLoad*h.loadFactor + 0.5) // +0.5 for ceiling
-	if maxLoad == 0 {
-		maxLoad = 1 // Minimum of 1
+	// Average load per unit of weight, not per node: normalizing by total
+	// weight (rather than node count) is what makes a weight-2 node's fair
+	// share exactly 2x a weight-1 node's, regardless of how many other
+	// nodes are in play.
+	totalWeight := 0
+	for _, node := range h.nodes {
+		totalWeight += node.Weight
 	}
+	avgLoad := float64(h.totalKeys) / float64(totalWeight)
 
-	// Update all nodes
+	// Max load = avgLoad * loadFactor rounded to the nearest integer, scaled
+	// per node by its weight so a 2x-weighted node accepts 2x the
+	// bounded-load ceiling
 	for _, node := range h.nodes {
+		maxLoad := int(avgLoad*h.loadFactor*float64(node.Weight) + 0.5) // +0.5 rounds to nearest
+		if maxLoad == 0 {
+			maxLoad = 1 // Minimum of 1
+		}
 		node.MaxLoad = maxLoad
 	}
 }
@@ -271,6 +496,51 @@ func (h *HashRing) rebalanceKeys() {
 	h.keyAssignment = newAssignment
 }
 
+// LoadFactor returns the current bounded-load factor.
+func (h *HashRing) LoadFactor() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.loadFactor
+}
+
+// SetLoadFactor updates the bounded-load factor and recalculates every
+// node's max load.
+func (h *HashRing) SetLoadFactor(loadFactor float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if loadFactor <= 0 {
+		loadFactor = 1.25
+	}
+	h.loadFactor = loadFactor
+	h.updateMaxLoads()
+}
+
+// BaseMaxLoad returns the bounded-load ceiling for a node of weight 1, i.e.
+// avgLoad * loadFactor rounded to the nearest integer (minimum 1) before
+// per-node weight scaling is applied, where avgLoad is normalized by total
+// weight rather than node count.
+func (h *HashRing) BaseMaxLoad() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.nodes) == 0 {
+		return 0
+	}
+
+	totalWeight := 0
+	for _, node := range h.nodes {
+		totalWeight += node.Weight
+	}
+	avgLoad := float64(h.totalKeys) / float64(totalWeight)
+	maxLoad := int(avgLoad*h.loadFactor + 0.5)
+	if maxLoad == 0 {
+		maxLoad = 1
+	}
+	return maxLoad
+}
+
 // GetNodes returns all nodes in the ring
 func (h *HashRing) GetNodes() []*Node {
 	h.mu.RLock()
@@ -295,6 +565,7 @@ func (h *HashRing) Stats() map[string]interface{} {
 			"host":     node.Host,
 			"load":     node.Load,
 			"max_load": node.MaxLoad,
+			"weight":   node.Weight,
 		})
 	}
 
@@ -3,6 +3,8 @@ package consistenthash
 import (
 	"fmt"
 	"testing"
+
+	"github.com/smol-go/smol-hash/pkg/smolhash"
 )
 
 func TestNewHashRing(t *testing.T) {
@@ -245,6 +247,171 @@ func TestNodeRemovalRebalancing(t *testing.T) {
 	}
 }
 
+func TestWeightedNode(t *testing.T) {
+	ring := NewHashRing(DefaultConfig())
+
+	node1 := NewWeightedNode("node1", "192.168.1.1:8080", 2)
+	node2 := NewNode("node2", "192.168.1.2:8080")
+
+	ring.AddNode(node1)
+	ring.AddNode(node2)
+
+	if len(ring.ring) != 150*2+150 {
+		t.Errorf("Expected %d virtual nodes, got %d", 150*2+150, len(ring.ring))
+	}
+
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		ring.GetNodeWithBoundedLoad(key)
+	}
+
+	if node1.MaxLoad <= node2.MaxLoad {
+		t.Errorf("Expected node1 (weight 2) to have a higher max load than node2, got %d <= %d", node1.MaxLoad, node2.MaxLoad)
+	}
+}
+
+// TestMaxLoadNormalizedByTotalWeight checks that updateMaxLoads derives
+// avgLoad from totalKeys/totalWeight, not totalKeys/len(nodes): with three
+// weight-1 nodes and one weight-10 node (totalWeight=13, totalNodes=4),
+// normalizing by node count instead would inflate every node's MaxLoad well
+// past its true fair share.
+func TestMaxLoadNormalizedByTotalWeight(t *testing.T) {
+	ring := NewHashRing(DefaultConfig())
+
+	heavy := NewWeightedNode("heavy", "192.168.1.1:8080", 10)
+	ring.AddNode(heavy)
+	ring.AddNode(NewNode("light1", "192.168.1.2:8080"))
+	ring.AddNode(NewNode("light2", "192.168.1.3:8080"))
+	ring.AddNode(NewNode("light3", "192.168.1.4:8080"))
+
+	ring.totalKeys = 130
+	ring.updateMaxLoads()
+
+	const totalWeight = 13
+	avgLoad := float64(ring.totalKeys) / totalWeight
+	wantLightMax := int(avgLoad*ring.loadFactor + 0.5)
+	wantHeavyMax := int(avgLoad*ring.loadFactor*10 + 0.5)
+
+	light1 := ring.nodes["light1"]
+	if light1.MaxLoad != wantLightMax {
+		t.Errorf("light MaxLoad = %d, want %d (avgLoad normalized by totalWeight)", light1.MaxLoad, wantLightMax)
+	}
+	if heavy.MaxLoad != wantHeavyMax {
+		t.Errorf("heavy MaxLoad = %d, want %d", heavy.MaxLoad, wantHeavyMax)
+	}
+}
+
+func TestUpdateWeight(t *testing.T) {
+	ring := NewHashRing(DefaultConfig())
+
+	node1 := NewNode("node1", "192.168.1.1:8080")
+	ring.AddNode(node1)
+
+	if err := ring.UpdateWeight("node1", 3); err != nil {
+		t.Fatalf("Failed to update weight: %v", err)
+	}
+
+	if len(ring.ring) != 450 {
+		t.Errorf("Expected 450 virtual nodes after weight update, got %d", len(ring.ring))
+	}
+
+	if err := ring.UpdateWeight("node1", 1); err != nil {
+		t.Fatalf("Failed to update weight: %v", err)
+	}
+
+	if len(ring.ring) != 150 {
+		t.Errorf("Expected 150 virtual nodes after weight revert, got %d", len(ring.ring))
+	}
+
+	if err := ring.UpdateWeight("nonexistent", 1); err == nil {
+		t.Error("Expected error when updating weight of non-existent node")
+	}
+}
+
+func TestGetClosestN(t *testing.T) {
+	ring := NewHashRing(DefaultConfig())
+
+	for i := 1; i <= 5; i++ {
+		node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+		ring.AddNode(node)
+	}
+
+	nodes, err := ring.GetClosestN("test-key", 3)
+	if err != nil {
+		t.Fatalf("Failed to get closest N: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(nodes))
+	}
+
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if seen[node.ID] {
+			t.Errorf("Duplicate node %s in closest N", node.ID)
+		}
+		seen[node.ID] = true
+	}
+
+	if _, err := ring.GetClosestN("test-key", 10); err != ErrNotEnoughNodes {
+		t.Errorf("Expected ErrNotEnoughNodes, got %v", err)
+	}
+}
+
+func TestGetClosestNWithLocality(t *testing.T) {
+	config := DefaultConfig()
+	config.LocalityKeys = []string{"zone"}
+	ring := NewHashRing(config)
+
+	zones := []string{"us-east", "us-east", "us-west", "us-west", "eu-west"}
+	for i, zone := range zones {
+		node := NewNode(fmt.Sprintf("node%d", i+1), fmt.Sprintf("192.168.1.%d:8080", i+1))
+		node.Metadata["zone"] = zone
+		ring.AddNode(node)
+	}
+
+	nodes, err := ring.GetClosestNWithLocality("test-key", 3)
+	if err != nil {
+		t.Fatalf("Failed to get closest N with locality: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(nodes))
+	}
+
+	seenZones := make(map[string]bool)
+	for _, node := range nodes {
+		zone := node.Metadata["zone"]
+		if seenZones[zone] {
+			t.Errorf("Two selected nodes share zone %s, expected diversity", zone)
+		}
+		seenZones[zone] = true
+	}
+}
+
+func TestGetLocal(t *testing.T) {
+	config := DefaultConfig()
+	ring := NewHashRing(config)
+
+	node1 := NewNode("node1", "192.168.1.1:8080")
+	node1.Metadata["zone"] = "us-east"
+	node2 := NewNode("node2", "192.168.1.2:8080")
+	node2.Metadata["zone"] = "us-west"
+
+	ring.AddNode(node1)
+	ring.AddNode(node2)
+
+	node, err := ring.GetLocal("test-key", map[string]string{"zone": "us-west"})
+	if err != nil {
+		t.Fatalf("Failed to get local node: %v", err)
+	}
+	if node.ID != "node2" {
+		t.Errorf("Expected node2, got %s", node.ID)
+	}
+
+	if _, err := ring.GetLocal("test-key", map[string]string{"zone": "eu-west"}); err != ErrNoLocalNode {
+		t.Errorf("Expected ErrNoLocalNode, got %v", err)
+	}
+}
+
 func BenchmarkGetNode(b *testing.B) {
 	ring := NewHashRing(DefaultConfig())
 
@@ -274,3 +441,30 @@ func BenchmarkGetNodeWithBoundedLoad(b *testing.B) {
 		ring.GetNodeWithBoundedLoad(key)
 	}
 }
+
+func BenchmarkHashers(b *testing.B) {
+	hashers := map[string]smolhash.HashFunc{
+		"sha256":  SHA256Hasher{},
+		"xxhash":  XXHasher{},
+		"murmur3": Murmur3Hasher{},
+	}
+
+	for name, hasher := range hashers {
+		b.Run(name, func(b *testing.B) {
+			config := DefaultConfig()
+			config.Hasher = hasher
+			ring := NewHashRing(config)
+
+			for i := 1; i <= 10; i++ {
+				node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+				ring.AddNode(node)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				key := fmt.Sprintf("key-%d", i)
+				ring.GetNode(key)
+			}
+		})
+	}
+}
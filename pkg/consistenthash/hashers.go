@@ -0,0 +1,42 @@
+package consistenthash
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/smol-go/smol-hash/pkg/smolhash"
+	"github.com/spaolacci/murmur3"
+)
+
+// SHA256Hasher is the default smolhash.HashFunc, kept for backward
+// compatibility with rings created before Hasher became configurable.
+type SHA256Hasher struct{}
+
+// Sum64 returns the first 8 bytes of the SHA-256 digest as a uint64.
+func (SHA256Hasher) Sum64(data []byte) uint64 {
+	sum := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// XXHasher is a smolhash.HashFunc backed by xxhash, the de-facto choice for
+// consistent hashing in ecosystems like go-redis and buraksezer/consistent.
+// It is roughly an order of magnitude faster than SHA-256.
+type XXHasher struct{}
+
+// Sum64 returns the xxhash64 digest of data.
+func (XXHasher) Sum64(data []byte) uint64 {
+	return xxhash.Sum64(data)
+}
+
+// Murmur3Hasher is a smolhash.HashFunc backed by Murmur3.
+type Murmur3Hasher struct{}
+
+// Sum64 returns the Murmur3 64-bit digest of data.
+func (Murmur3Hasher) Sum64(data []byte) uint64 {
+	return murmur3.Sum64(data)
+}
+
+var _ smolhash.HashFunc = SHA256Hasher{}
+var _ smolhash.HashFunc = XXHasher{}
+var _ smolhash.HashFunc = Murmur3Hasher{}
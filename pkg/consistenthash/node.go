@@ -6,20 +6,35 @@ type Node struct {
 	Host     string            // Host address (e.g., "192.168.1.1:8080")
 	Load     int               // Current number of keys assigned to this node
 	MaxLoad  int               // Maximum allowed load for bounded loads
+	Weight   int               // Relative capacity; scales virtual node count and MaxLoad (default: 1)
 	Metadata map[string]string // Additional metadata
 }
 
-// NewNode creates a new node with the given ID and host
+// NewNode creates a new node with the given ID and host, and a default weight of 1.
 func NewNode(id, host string) *Node {
 	return &Node{
 		ID:       id,
 		Host:     host,
 		Load:     0,
 		MaxLoad:  0,
+		Weight:   1,
 		Metadata: make(map[string]string),
 	}
 }
 
+// NewWeightedNode creates a new node with the given ID, host, and weight. A
+// node with weight 2 gets twice the virtual nodes (and twice the bounded-load
+// ceiling) of a node with weight 1, to represent heterogeneous fleets without
+// spinning up phantom "shadow" node IDs.
+func NewWeightedNode(id, host string, weight int) *Node {
+	if weight <= 0 {
+		weight = 1
+	}
+	node := NewNode(id, host)
+	node.Weight = weight
+	return node
+}
+
 // CanAcceptKey checks if the node can accept more keys (for bounded loads)
 func (n *Node) CanAcceptKey() bool {
 	if n.MaxLoad == 0 {
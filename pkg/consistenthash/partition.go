@@ -0,0 +1,289 @@
+package consistenthash
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// PartitionConfig holds configuration for a PartitionRing.
+type PartitionConfig struct {
+	PartitionCount int     // Number of partitions, rounded up to a power of two (default: 1024)
+	VirtualNodes   int     // Number of virtual nodes per physical node used to build the ring (default: 150)
+	LoadFactor     float64 // Load factor for bounded partition assignment (default: 1.25)
+}
+
+// DefaultPartitionConfig returns sensible defaults.
+func DefaultPartitionConfig() PartitionConfig {
+	return PartitionConfig{
+		PartitionCount: 1024,
+		VirtualNodes:   150,
+		LoadFactor:     1.25,
+	}
+}
+
+// PartitionRing implements partition-based consistent hashing: a fixed number
+// of partitions is assigned to nodes up front, and GetNode becomes a hash +
+// array lookup instead of a per-request ring walk.
+type PartitionRing struct {
+	mu             sync.RWMutex
+	nodes          map[string]*Node  // nodeID -> Node
+	ring           []uint32          // sorted virtual node hashes, used only when (re)building the table
+	ringMap        map[uint32]string // hash -> nodeID
+	virtualNodes   int
+	partitionCount int
+	loadFactor     float64
+	table          []string // partID -> nodeID
+}
+
+// NewPartitionRing creates a new partition ring. PartitionCount is rounded up
+// to the next power of two if it isn't one already.
+func NewPartitionRing(config PartitionConfig) *PartitionRing {
+	if config.PartitionCount <= 0 {
+		config.PartitionCount = 1024
+	}
+	if config.VirtualNodes <= 0 {
+		config.VirtualNodes = 150
+	}
+	if config.LoadFactor <= 0 {
+		config.LoadFactor = 1.25
+	}
+
+	return &PartitionRing{
+		nodes:          make(map[string]*Node),
+		ringMap:        make(map[uint32]string),
+		virtualNodes:   config.VirtualNodes,
+		partitionCount: nextPowerOfTwo(config.PartitionCount),
+		loadFactor:     config.LoadFactor,
+		table:          make([]string, nextPowerOfTwo(config.PartitionCount)),
+	}
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// hash generates a 32-bit hash from the first 4 bytes of the SHA-256
+// digest. Unlike HashRing, the hash function is fixed and 32 bits wide.
+func (p *PartitionRing) hash(key string) uint32 {
+	hasher := sha256.New()
+	hasher.Write([]byte(key))
+	hashBytes := hasher.Sum(nil)
+	return binary.BigEndian.Uint32(hashBytes[:4])
+}
+
+// AddNode adds a physical node and rebuilds the partition table.
+func (p *PartitionRing) AddNode(node *Node) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.nodes[node.ID]; exists {
+		return fmt.Errorf("node %s already exists: %w", node.ID, ErrNodeExists)
+	}
+
+	p.nodes[node.ID] = node
+
+	for i := 0; i < p.virtualNodes; i++ {
+		virtualKey := fmt.Sprintf("%s#%d", node.ID, i)
+		hashVal := p.hash(virtualKey)
+		p.ring = append(p.ring, hashVal)
+		p.ringMap[hashVal] = node.ID
+	}
+
+	sort.Slice(p.ring, func(i, j int) bool {
+		return p.ring[i] < p.ring[j]
+	})
+
+	p.rebuildTable()
+
+	return nil
+}
+
+// RemoveNode removes a physical node and rebuilds the partition table.
+func (p *PartitionRing) RemoveNode(nodeID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.nodes[nodeID]; !exists {
+		return fmt.Errorf("node %s not found: %w", nodeID, ErrNodeNotFound)
+	}
+
+	newRing := make([]uint32, 0, len(p.ring))
+	for _, hashVal := range p.ring {
+		if p.ringMap[hashVal] != nodeID {
+			newRing = append(newRing, hashVal)
+		} else {
+			delete(p.ringMap, hashVal)
+		}
+	}
+	p.ring = newRing
+
+	delete(p.nodes, nodeID)
+
+	p.rebuildTable()
+
+	return nil
+}
+
+// rebuildTable reassigns every partition to an owner, in partition id order,
+// which gives deterministic, minimal-movement rebalancing independent of
+// insertion order. It must be called with p.mu held.
+func (p *PartitionRing) rebuildTable() {
+	table := make([]string, p.partitionCount)
+
+	if len(p.nodes) == 0 || len(p.ring) == 0 {
+		p.table = table
+		return
+	}
+
+	avgLoad := float64(p.partitionCount) / float64(len(p.nodes))
+	maxLoad := int(avgLoad*p.loadFactor + 0.5)
+	if maxLoad == 0 {
+		maxLoad = 1
+	}
+
+	load := make(map[string]int, len(p.nodes))
+
+	for partID := 0; partID < p.partitionCount; partID++ {
+		var buf [4]byte
+		binary.BigEndian.PutUint32(buf[:], uint32(partID))
+		hashVal := p.hash(string(buf[:]))
+
+		startIdx := p.search(hashVal)
+		ringLen := len(p.ring)
+
+		owner := ""
+		for i := 0; i < ringLen; i++ {
+			idx := (startIdx + i) % ringLen
+			nodeID := p.ringMap[p.ring[idx]]
+			if load[nodeID] < maxLoad {
+				owner = nodeID
+				break
+			}
+		}
+		if owner == "" {
+			// Every node is at the cap; fall back to the primary owner.
+			owner = p.ringMap[p.ring[startIdx]]
+		}
+
+		load[owner]++
+		table[partID] = owner
+	}
+
+	p.table = table
+}
+
+// search performs binary search to find the first ring entry >= hashVal.
+func (p *PartitionRing) search(hashVal uint32) int {
+	idx := sort.Search(len(p.ring), func(i int) bool {
+		return p.ring[i] >= hashVal
+	})
+	if idx >= len(p.ring) {
+		idx = 0
+	}
+	return idx
+}
+
+// GetPartitionID returns the partition a key falls into.
+func (p *PartitionRing) GetPartitionID(key string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return int(p.hash(key)) & (p.partitionCount - 1)
+}
+
+// GetPartitionOwner returns the node currently assigned to partID.
+func (p *PartitionRing) GetPartitionOwner(partID int) (*Node, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if partID < 0 || partID >= len(p.table) {
+		return nil, fmt.Errorf("partition %d out of range", partID)
+	}
+
+	nodeID := p.table[partID]
+	if nodeID == "" {
+		return nil, ErrNoNodes
+	}
+
+	return p.nodes[nodeID], nil
+}
+
+// GetNode returns the node responsible for a given key: a hash plus array
+// index, with no per-request tree or binary search.
+func (p *PartitionRing) GetNode(key string) (*Node, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(p.nodes) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	partID := int(p.hash(key)) & (p.partitionCount - 1)
+	nodeID := p.table[partID]
+	if nodeID == "" {
+		return nil, ErrNoNodes
+	}
+
+	return p.nodes[nodeID], nil
+}
+
+// GetClosestNForPartition returns the first n distinct physical nodes
+// encountered walking the ring clockwise from the partition's assigned
+// position, for replica placement on top of the partition table.
+func (p *PartitionRing) GetClosestNForPartition(partID, n int) ([]*Node, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if n > len(p.nodes) {
+		return nil, ErrNotEnoughNodes
+	}
+	if partID < 0 || partID >= p.partitionCount {
+		return nil, fmt.Errorf("partition %d out of range", partID)
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(partID))
+	hashVal := p.hash(string(buf[:]))
+	startIdx := p.search(hashVal)
+	ringLen := len(p.ring)
+
+	seen := make(map[string]bool, n)
+	result := make([]*Node, 0, n)
+
+	for i := 0; i < ringLen && len(result) < n; i++ {
+		idx := (startIdx + i) % ringLen
+		nodeID := p.ringMap[p.ring[idx]]
+		if seen[nodeID] {
+			continue
+		}
+		seen[nodeID] = true
+		result = append(result, p.nodes[nodeID])
+	}
+
+	return result, nil
+}
+
+// LoadDistribution returns the number of partitions assigned to each node,
+// for observability (not the number of keys).
+func (p *PartitionRing) LoadDistribution() map[string]int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	dist := make(map[string]int, len(p.nodes))
+	for _, nodeID := range p.table {
+		if nodeID != "" {
+			dist[nodeID]++
+		}
+	}
+	return dist
+}
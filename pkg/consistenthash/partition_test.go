@@ -0,0 +1,117 @@
+package consistenthash
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewPartitionRing(t *testing.T) {
+	ring := NewPartitionRing(DefaultPartitionConfig())
+
+	if ring.partitionCount != 1024 {
+		t.Errorf("Expected 1024 partitions, got %d", ring.partitionCount)
+	}
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	cases := map[int]int{1: 1, 2: 2, 3: 4, 271: 512, 1024: 1024, 1025: 2048}
+	for in, want := range cases {
+		if got := nextPowerOfTwo(in); got != want {
+			t.Errorf("nextPowerOfTwo(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestPartitionRingGetNode(t *testing.T) {
+	ring := NewPartitionRing(PartitionConfig{PartitionCount: 271, VirtualNodes: 100, LoadFactor: 1.25})
+
+	for i := 1; i <= 3; i++ {
+		node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+		if err := ring.AddNode(node); err != nil {
+			t.Fatalf("Failed to add node: %v", err)
+		}
+	}
+
+	key := "test-key-123"
+	node, err := ring.GetNode(key)
+	if err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		n, _ := ring.GetNode(key)
+		if n.ID != node.ID {
+			t.Errorf("Key mapped to different node on iteration %d", i)
+		}
+	}
+}
+
+func TestPartitionRingLoadDistribution(t *testing.T) {
+	ring := NewPartitionRing(PartitionConfig{PartitionCount: 256, VirtualNodes: 150, LoadFactor: 1.25})
+
+	for i := 1; i <= 4; i++ {
+		node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+		ring.AddNode(node)
+	}
+
+	dist := ring.LoadDistribution()
+	total := 0
+	for _, count := range dist {
+		total += count
+	}
+
+	if total != 256 {
+		t.Errorf("Expected 256 partitions assigned, got %d", total)
+	}
+
+	expected := 256 / 4
+	tolerance := expected / 2
+	for nodeID, count := range dist {
+		if count < expected-tolerance || count > expected+tolerance {
+			t.Logf("Warning: node %s has unbalanced partition count: %d (expected ~%d)", nodeID, count, expected)
+		}
+	}
+}
+
+func TestGetClosestNForPartition(t *testing.T) {
+	ring := NewPartitionRing(PartitionConfig{PartitionCount: 128, VirtualNodes: 100, LoadFactor: 1.25})
+
+	for i := 1; i <= 5; i++ {
+		node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+		ring.AddNode(node)
+	}
+
+	nodes, err := ring.GetClosestNForPartition(0, 3)
+	if err != nil {
+		t.Fatalf("Failed to get closest N for partition: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(nodes))
+	}
+
+	if _, err := ring.GetClosestNForPartition(0, 10); err != ErrNotEnoughNodes {
+		t.Errorf("Expected ErrNotEnoughNodes, got %v", err)
+	}
+}
+
+func TestPartitionRingRemoveNode(t *testing.T) {
+	ring := NewPartitionRing(PartitionConfig{PartitionCount: 128, VirtualNodes: 100, LoadFactor: 1.25})
+
+	for i := 1; i <= 3; i++ {
+		node := NewNode(fmt.Sprintf("node%d", i), fmt.Sprintf("192.168.1.%d:8080", i))
+		ring.AddNode(node)
+	}
+
+	if err := ring.RemoveNode("node2"); err != nil {
+		t.Fatalf("Failed to remove node: %v", err)
+	}
+
+	dist := ring.LoadDistribution()
+	if _, exists := dist["node2"]; exists {
+		t.Error("Expected node2 to have no partitions after removal")
+	}
+
+	if err := ring.RemoveNode("nonexistent"); err == nil {
+		t.Error("Expected error when removing non-existent node")
+	}
+}
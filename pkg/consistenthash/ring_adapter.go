@@ -0,0 +1,110 @@
+package consistenthash
+
+import "github.com/smol-go/smol-hash/pkg/smolhash"
+
+// RingAdapter adapts a HashRing to the smolhash.Ring interface, translating
+// between HashRing's *Node-based API and the server-ID-based API that Ring
+// callers depend on. This lets callers code against smolhash.Ring and swap
+// between this ring and smolhash.RendezvousHash without touching call
+// sites. PartitionRing does not implement smolhash.Ring and has no adapter.
+type RingAdapter struct {
+	ring *HashRing
+}
+
+// NewRingAdapter wraps an existing HashRing as a smolhash.Ring.
+func NewRingAdapter(ring *HashRing) *RingAdapter {
+	return &RingAdapter{ring: ring}
+}
+
+// AddServer adds a node whose ID and host are both serverID.
+func (a *RingAdapter) AddServer(serverID string) error {
+	return a.ring.AddNode(NewNode(serverID, serverID))
+}
+
+// RemoveServer removes the node with the given ID.
+func (a *RingAdapter) RemoveServer(serverID string) error {
+	return a.ring.RemoveNode(serverID)
+}
+
+// GetServer returns the ID of the node responsible for key.
+func (a *RingAdapter) GetServer(key string) (string, error) {
+	node, err := a.ring.GetNode(key)
+	if err != nil {
+		return "", err
+	}
+	return node.ID, nil
+}
+
+// GetServerWithLoad returns the ID of the node responsible for key,
+// honoring the bounded-load cap.
+func (a *RingAdapter) GetServerWithLoad(key string) (string, error) {
+	node, err := a.ring.GetNodeWithBoundedLoad(key)
+	if err != nil {
+		return "", err
+	}
+	return node.ID, nil
+}
+
+// GetServerLoad returns the current load for a node.
+func (a *RingAdapter) GetServerLoad(serverID string) (int64, error) {
+	a.ring.mu.RLock()
+	defer a.ring.mu.RUnlock()
+
+	node, exists := a.ring.nodes[serverID]
+	if !exists {
+		return 0, ErrNodeNotFound
+	}
+	return int64(node.Load), nil
+}
+
+// GetServers returns the IDs of every node in the ring.
+func (a *RingAdapter) GetServers() []string {
+	nodes := a.ring.GetNodes()
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+// IncrementLoad increases a node's load counter by amount.
+func (a *RingAdapter) IncrementLoad(serverID string, amount int64) error {
+	a.ring.mu.Lock()
+	defer a.ring.mu.Unlock()
+
+	node, exists := a.ring.nodes[serverID]
+	if !exists {
+		return ErrNodeNotFound
+	}
+	for i := int64(0); i < amount; i++ {
+		node.IncrementLoad()
+	}
+	return nil
+}
+
+// DecrementLoad decreases a node's load counter by amount.
+func (a *RingAdapter) DecrementLoad(serverID string, amount int64) error {
+	a.ring.mu.Lock()
+	defer a.ring.mu.Unlock()
+
+	node, exists := a.ring.nodes[serverID]
+	if !exists {
+		return ErrNodeNotFound
+	}
+	for i := int64(0); i < amount; i++ {
+		node.DecrementLoad()
+	}
+	return nil
+}
+
+// GetMaxLoad returns the bounded-load ceiling for an unweighted node.
+func (a *RingAdapter) GetMaxLoad() int64 {
+	return int64(a.ring.BaseMaxLoad())
+}
+
+// SetLoadBalanceFactor updates the ring's bounded-load factor.
+func (a *RingAdapter) SetLoadBalanceFactor(epsilon float64) {
+	a.ring.SetLoadFactor(epsilon)
+}
+
+var _ smolhash.Ring = (*RingAdapter)(nil)
@@ -0,0 +1,49 @@
+package consistenthash
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smol-go/smol-hash/pkg/smolhash"
+)
+
+func TestRingAdapterSatisfiesRing(t *testing.T) {
+	var _ smolhash.Ring = NewRingAdapter(NewHashRing(DefaultConfig()))
+}
+
+func TestRingAdapterBasics(t *testing.T) {
+	adapter := NewRingAdapter(NewHashRing(DefaultConfig()))
+
+	if err := adapter.AddServer("server1"); err != nil {
+		t.Fatalf("Failed to add server: %v", err)
+	}
+	if err := adapter.AddServer("server2"); err != nil {
+		t.Fatalf("Failed to add server: %v", err)
+	}
+
+	server, err := adapter.GetServer("key-1")
+	if err != nil {
+		t.Fatalf("Failed to get server: %v", err)
+	}
+	if server != "server1" && server != "server2" {
+		t.Errorf("Unexpected server: %s", server)
+	}
+
+	if err := adapter.IncrementLoad(server, 5); err != nil {
+		t.Fatalf("Failed to increment load: %v", err)
+	}
+	load, err := adapter.GetServerLoad(server)
+	if err != nil {
+		t.Fatalf("Failed to get load: %v", err)
+	}
+	if load != 5 {
+		t.Errorf("Expected load 5, got %d", load)
+	}
+
+	if err := adapter.RemoveServer(server); err != nil {
+		t.Fatalf("Failed to remove server: %v", err)
+	}
+	if _, err := adapter.GetServerLoad(server); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package smolhash
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+// fnv64aHasher is the default HashFunc, used when no Hasher is configured.
+type fnv64aHasher struct{}
+
+func (fnv64aHasher) Sum64(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64()
+}
+
+// RendezvousConfig holds configuration for a RendezvousHash.
+type RendezvousConfig struct {
+	Hasher            HashFunc // Hash function used to score servers (default: FNV-1a 64-bit)
+	LoadBalanceFactor float64  // Bounded-load factor, e.g. 1.25 (default: 1.25)
+}
+
+// DefaultRendezvousConfig returns sensible defaults.
+func DefaultRendezvousConfig() RendezvousConfig {
+	return RendezvousConfig{
+		Hasher:            fnv64aHasher{},
+		LoadBalanceFactor: 1.25,
+	}
+}
+
+// RendezvousHash implements Rendezvous (Highest-Random-Weight) hashing: for
+// a given key, every server is scored and the highest-scoring one wins.
+// There is no ring and no virtual nodes; lookup is O(N) in the number of
+// servers but branch-predictable and cache-friendly for small N.
+type RendezvousHash struct {
+	mu                sync.RWMutex
+	hasher            HashFunc
+	loadBalanceFactor float64
+	servers           map[string]*Server
+}
+
+// NewRendezvousHash creates a new RendezvousHash.
+func NewRendezvousHash(config RendezvousConfig) *RendezvousHash {
+	if config.Hasher == nil {
+		config.Hasher = fnv64aHasher{}
+	}
+	if config.LoadBalanceFactor <= 0 {
+		config.LoadBalanceFactor = 1.25
+	}
+
+	return &RendezvousHash{
+		hasher:            config.Hasher,
+		loadBalanceFactor: config.LoadBalanceFactor,
+		servers:           make(map[string]*Server),
+	}
+}
+
+// score computes the rendezvous weight of serverID for key.
+func (r *RendezvousHash) score(serverID, key string) uint64 {
+	return r.hasher.Sum64([]byte(serverID + key))
+}
+
+// AddServer adds a server to the pool.
+func (r *RendezvousHash) AddServer(serverID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.servers[serverID]; exists {
+		return ErrServerExists
+	}
+
+	r.servers[serverID] = &Server{ID: serverID, Metadata: make(map[string]interface{})}
+	return nil
+}
+
+// RemoveServer removes a server from the pool.
+func (r *RendezvousHash) RemoveServer(serverID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.servers[serverID]; !exists {
+		return ErrServerNotFound
+	}
+
+	delete(r.servers, serverID)
+	return nil
+}
+
+// GetServer returns the highest-scoring server for key, with ties broken by
+// serverID lexicographically.
+func (r *RendezvousHash) GetServer(key string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.servers) == 0 {
+		return "", ErrNoServers
+	}
+
+	var best string
+	var bestScore uint64
+	for id := range r.servers {
+		s := r.score(id, key)
+		if best == "" || s > bestScore || (s == bestScore && id < best) {
+			best = id
+			bestScore = s
+		}
+	}
+
+	return best, nil
+}
+
+// GetServerWithLoad returns the highest-scoring server for key that is still
+// under the bounded-load cap, ranking all servers by score and picking the
+// best one with capacity remaining.
+func (r *RendezvousHash) GetServerWithLoad(key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.servers) == 0 {
+		return "", ErrNoServers
+	}
+
+	ranked := r.rankByScore(key)
+	maxLoad := r.maxLoadLocked()
+
+	for _, id := range ranked {
+		server := r.servers[id]
+		if maxLoad == 0 || server.Load < maxLoad {
+			server.Load++
+			return id, nil
+		}
+	}
+
+	return "", ErrAllServersOverloaded
+}
+
+// GetClosestN returns the top-n servers for key, ranked by score. This is the
+// natural replica/preference list for rendezvous hashing.
+func (r *RendezvousHash) GetClosestN(key string, n int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if n > len(r.servers) {
+		return nil, ErrNotEnoughNodes
+	}
+
+	ranked := r.rankByScore(key)
+	return ranked[:n], nil
+}
+
+// rankByScore returns every server ID ordered by descending score for key,
+// ties broken by serverID. Must be called with r.mu held.
+func (r *RendezvousHash) rankByScore(key string) []string {
+	ranked := make([]string, 0, len(r.servers))
+	scores := make(map[string]uint64, len(r.servers))
+	for id := range r.servers {
+		ranked = append(ranked, id)
+		scores[id] = r.score(id, key)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if scores[ranked[i]] != scores[ranked[j]] {
+			return scores[ranked[i]] > scores[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+
+	return ranked
+}
+
+// GetServerLoad returns the current load for a server.
+func (r *RendezvousHash) GetServerLoad(serverID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	server, exists := r.servers[serverID]
+	if !exists {
+		return 0, ErrServerNotFound
+	}
+	return server.Load, nil
+}
+
+// GetServers returns all server IDs in the pool.
+func (r *RendezvousHash) GetServers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	servers := make([]string, 0, len(r.servers))
+	for id := range r.servers {
+		servers = append(servers, id)
+	}
+	return servers
+}
+
+// IncrementLoad increases a server's load counter by amount.
+func (r *RendezvousHash) IncrementLoad(serverID string, amount int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	server, exists := r.servers[serverID]
+	if !exists {
+		return ErrServerNotFound
+	}
+	server.Load += amount
+	return nil
+}
+
+// DecrementLoad decreases a server's load counter by amount, floored at zero.
+func (r *RendezvousHash) DecrementLoad(serverID string, amount int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	server, exists := r.servers[serverID]
+	if !exists {
+		return ErrServerNotFound
+	}
+	server.Load -= amount
+	if server.Load < 0 {
+		server.Load = 0
+	}
+	return nil
+}
+
+// GetMaxLoad returns the current bounded-load cap across all servers.
+func (r *RendezvousHash) GetMaxLoad() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.maxLoadLocked()
+}
+
+// maxLoadLocked computes the bounded-load cap. Must be called with r.mu held.
+func (r *RendezvousHash) maxLoadLocked() int64 {
+	if len(r.servers) == 0 {
+		return 0
+	}
+
+	var totalLoad int64
+	for _, server := range r.servers {
+		totalLoad += server.Load
+	}
+
+	avgLoad := float64(totalLoad+1) / float64(len(r.servers))
+	maxLoad := int64(avgLoad*r.loadBalanceFactor + 0.5)
+	if maxLoad == 0 {
+		maxLoad = 1
+	}
+	return maxLoad
+}
+
+// SetLoadBalanceFactor updates the bounded-load factor used by GetServerWithLoad.
+func (r *RendezvousHash) SetLoadBalanceFactor(epsilon float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.loadBalanceFactor = epsilon
+}
+
+var _ Ring = (*RendezvousHash)(nil)
@@ -0,0 +1,93 @@
+package smolhash
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRendezvousGetServer(t *testing.T) {
+	r := NewRendezvousHash(DefaultRendezvousConfig())
+
+	for i := 1; i <= 3; i++ {
+		if err := r.AddServer(fmt.Sprintf("server%d", i)); err != nil {
+			t.Fatalf("Failed to add server: %v", err)
+		}
+	}
+
+	key := "test-key-123"
+	server, err := r.GetServer(key)
+	if err != nil {
+		t.Fatalf("Failed to get server: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		s, _ := r.GetServer(key)
+		if s != server {
+			t.Errorf("Key mapped to different server on iteration %d", i)
+		}
+	}
+}
+
+func TestRendezvousNoServers(t *testing.T) {
+	r := NewRendezvousHash(DefaultRendezvousConfig())
+
+	if _, err := r.GetServer("key"); err != ErrNoServers {
+		t.Errorf("Expected ErrNoServers, got %v", err)
+	}
+}
+
+func TestRendezvousGetClosestN(t *testing.T) {
+	r := NewRendezvousHash(DefaultRendezvousConfig())
+
+	for i := 1; i <= 5; i++ {
+		r.AddServer(fmt.Sprintf("server%d", i))
+	}
+
+	top, err := r.GetClosestN("test-key", 3)
+	if err != nil {
+		t.Fatalf("Failed to get closest N: %v", err)
+	}
+	if len(top) != 3 {
+		t.Errorf("Expected 3 servers, got %d", len(top))
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range top {
+		if seen[id] {
+			t.Errorf("Duplicate server %s in closest N", id)
+		}
+		seen[id] = true
+	}
+
+	if _, err := r.GetClosestN("test-key", 10); err != ErrNotEnoughNodes {
+		t.Errorf("Expected ErrNotEnoughNodes when n > servers, got %v", err)
+	}
+}
+
+func TestRendezvousBoundedLoad(t *testing.T) {
+	r := NewRendezvousHash(DefaultRendezvousConfig())
+
+	for i := 1; i <= 3; i++ {
+		r.AddServer(fmt.Sprintf("server%d", i))
+	}
+
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		if _, err := r.GetServerWithLoad(key); err != nil {
+			t.Fatalf("Failed to assign key %s: %v", key, err)
+		}
+	}
+
+	var total int64
+	for _, id := range r.GetServers() {
+		load, _ := r.GetServerLoad(id)
+		total += load
+		if load > r.GetMaxLoad()+5 {
+			t.Errorf("Server %s exceeded max load: %d > %d", id, load, r.GetMaxLoad())
+		}
+	}
+
+	if total != 300 {
+		t.Errorf("Total load mismatch: expected 300, got %d", total)
+	}
+}
@@ -5,7 +5,10 @@ import "errors"
 var (
 	ErrNoServers            = errors.New("no servers available")
 	ErrServerNotFound       = errors.New("server not found")
+	ErrServerExists         = errors.New("server already exists")
 	ErrAllServersOverloaded = errors.New("all servers are overloaded")
+	ErrKeyNotFound          = errors.New("key not found")
+	ErrNotEnoughNodes       = errors.New("not enough distinct nodes available")
 )
 
 type Server struct {
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetNodesReturnsDistinctNodes(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	nodes, err := ch.GetNodes("user:1001", 3)
+	if err != nil {
+		t.Fatalf("GetNodes failed: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d: %v", len(nodes), nodes)
+	}
+
+	seen := make(map[string]bool, 3)
+	for _, n := range nodes {
+		if seen[n] {
+			t.Fatalf("duplicate node %s in %v", n, nodes)
+		}
+		seen[n] = true
+	}
+}
+
+func TestGetNodesStablePrefixWhenTopologyUnchanged(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+	ch.AddNode("server4")
+	ch.AddNode("server5")
+
+	first, err := ch.GetNodes("user:1001", 4)
+	if err != nil {
+		t.Fatalf("GetNodes failed: %v", err)
+	}
+
+	for _, n := range []int{1, 2, 3} {
+		got, err := ch.GetNodes("user:1001", n)
+		if err != nil {
+			t.Fatalf("GetNodes(%d) failed: %v", n, err)
+		}
+		if !reflect.DeepEqual(got, first[:n]) {
+			t.Fatalf("GetNodes(%d) = %v, want stable prefix %v", n, got, first[:n])
+		}
+	}
+}
+
+func TestGetNodesExcludingSkipsUnhealthy(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+	ch.AddNode("server3")
+
+	nodes, err := ch.GetNodesExcluding("user:1001", 2, []string{"server2"})
+	if err != nil {
+		t.Fatalf("GetNodesExcluding failed: %v", err)
+	}
+	for _, n := range nodes {
+		if n == "server2" {
+			t.Fatalf("excluded node server2 present in result: %v", nodes)
+		}
+	}
+}
+
+func TestGetNodesNotEnoughNodes(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNode("server1")
+	ch.AddNode("server2")
+
+	if _, err := ch.GetNodes("user:1001", 3); err == nil {
+		t.Fatal("expected an error requesting more replicas than nodes exist")
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+)
+
+const (
+	skipListMaxLevel = 16
+	skipListP        = 0.5
+)
+
+// skipListNode is one entry in the skip list: a ring hash mapped to the
+// physical node name that owns it.
+type skipListNode struct {
+	key     uint64
+	value   string
+	forward []*skipListNode
+}
+
+// skipList is a concurrent, level-indexed sorted map from ring hash to node
+// name. It replaces the append+slices.Sort ring: inserting or deleting a
+// single virtual node is O(log n) instead of requiring a full O(n log n)
+// re-sort, which matters under frequent topology churn.
+type skipList struct {
+	mu     sync.RWMutex
+	head   *skipListNode
+	level  int
+	length int
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:  &skipListNode{forward: make([]*skipListNode, skipListMaxLevel)},
+		level: 1,
+	}
+}
+
+func (s *skipList) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// Insert adds key->value to the list. If key already exists, its value is
+// overwritten.
+func (s *skipList) Insert(key uint64, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update := make([]*skipListNode, skipListMaxLevel)
+	curr := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.forward[i] != nil && curr.forward[i].key < key {
+			curr = curr.forward[i]
+		}
+		update[i] = curr
+	}
+
+	if next := curr.forward[0]; next != nil && next.key == key {
+		next.value = value
+		return
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = s.head
+		}
+		s.level = level
+	}
+
+	node := &skipListNode{key: key, value: value, forward: make([]*skipListNode, level)}
+	for i := 0; i < level; i++ {
+		node.forward[i] = update[i].forward[i]
+		update[i].forward[i] = node
+	}
+	s.length++
+}
+
+// Delete removes key from the list, reporting whether it was present.
+func (s *skipList) Delete(key uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update := make([]*skipListNode, skipListMaxLevel)
+	curr := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.forward[i] != nil && curr.forward[i].key < key {
+			curr = curr.forward[i]
+		}
+		update[i] = curr
+	}
+
+	target := curr.forward[0]
+	if target == nil || target.key != key {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].forward[i] != target {
+			break
+		}
+		update[i].forward[i] = target.forward[i]
+	}
+	for s.level > 1 && s.head.forward[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+	return true
+}
+
+// Ceiling returns the entry with the smallest key >= target, wrapping
+// around to the smallest key in the list if target is past the end. The
+// second return value is false only when the list is empty.
+func (s *skipList) Ceiling(target uint64) (uint64, string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.length == 0 {
+		return 0, "", false
+	}
+
+	curr := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.forward[i] != nil && curr.forward[i].key < target {
+			curr = curr.forward[i]
+		}
+	}
+
+	if next := curr.forward[0]; next != nil {
+		return next.key, next.value, true
+	}
+
+	first := s.head.forward[0]
+	return first.key, first.value, true
+}
+
+// Walk visits entries in ascending key order starting at the ceiling of
+// start, wrapping around to the beginning of the list once, and stops as
+// soon as fn returns false or every entry has been visited. It is used to
+// scan the ring clockwise from a hashed key looking for a node with spare
+// capacity.
+func (s *skipList) Walk(start uint64, fn func(key uint64, value string) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.length == 0 {
+		return
+	}
+
+	curr := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.forward[i] != nil && curr.forward[i].key < start {
+			curr = curr.forward[i]
+		}
+	}
+
+	node := curr.forward[0]
+	if node == nil {
+		node = s.head.forward[0]
+	}
+
+	for i := 0; i < s.length; i++ {
+		if !fn(node.key, node.value) {
+			return
+		}
+		node = node.forward[0]
+		if node == nil {
+			node = s.head.forward[0]
+		}
+	}
+}
+
+// Len returns the number of entries currently in the list.
+func (s *skipList) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.length
+}
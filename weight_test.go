@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestAddNodeWithWeightScalesSustainedLoad checks that weight scales the
+// bounded-load ceiling itself, not just the virtual node count used to win
+// the ring walk. A heavier node should carry proportionally more sustained
+// load than a weight-1 peer once both are at capacity.
+func TestAddNodeWithWeightScalesSustainedLoad(t *testing.T) {
+	const weight = 4
+
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNodeWithWeight("heavy", weight)
+	ch.AddNodeWithWeight("light", 1)
+
+	for i := 0; i < 20000; i++ {
+		if _, err := ch.GetNode(fmt.Sprintf("key-%d", i)); err != nil {
+			t.Fatalf("GetNode failed at key %d: %v", i, err)
+		}
+	}
+
+	heavyLoad := ch.nodes["heavy"].load
+	lightLoad := ch.nodes["light"].load
+	if lightLoad == 0 {
+		t.Fatal("light node received no load")
+	}
+
+	ratio := float64(heavyLoad) / float64(lightLoad)
+	if ratio < weight*0.5 {
+		t.Errorf("heavy/light load ratio = %.2f, want roughly %d (weight), got heavy=%d light=%d", ratio, weight, heavyLoad, lightLoad)
+	}
+}
+
+// TestNodeCapacityNormalizedByTotalWeight checks that per-node capacity is
+// derived from totalLoad/totalWeight, not totalLoad/totalNodeCount: with
+// three weight-1 nodes and one weight-10 node (totalWeight=13, but
+// totalNodes=4), normalizing by node count would inflate every node's
+// capacity by a 13/4 factor relative to its true fair share.
+func TestNodeCapacityNormalizedByTotalWeight(t *testing.T) {
+	ch := NewConsistentHash(150, 1.25)
+	ch.AddNodeWithWeight("heavy", 10)
+	ch.AddNode("light1")
+	ch.AddNode("light2")
+	ch.AddNode("light3")
+
+	ch.mu.Lock()
+	ch.nodes["heavy"].load = 100
+	ch.nodes["light1"].load = 10
+	ch.nodes["light2"].load = 10
+	ch.nodes["light3"].load = 10
+	base := ch.baseCapacityLocked()
+	ch.mu.Unlock()
+
+	const totalLoad = 130
+	const totalWeight = 13
+	wantBase := (totalLoad + totalWeight) / totalWeight
+	if base != wantBase {
+		t.Fatalf("baseCapacityLocked() = %d, want %d (ceil((totalLoad+1)/totalWeight))", base, wantBase)
+	}
+
+	ch.mu.RLock()
+	capHeavy := ch.nodeCapacityLocked(base, ch.nodes["heavy"])
+	capLight := ch.nodeCapacityLocked(base, ch.nodes["light1"])
+	ch.mu.RUnlock()
+
+	wantCapLight := int(math.Ceil(float64(base) * ch.loadFactor))
+	wantCapHeavy := int(math.Ceil(float64(base) * ch.loadFactor * 10))
+	if capLight != wantCapLight {
+		t.Errorf("capLight = %d, want %d", capLight, wantCapLight)
+	}
+	if capHeavy != wantCapHeavy {
+		t.Errorf("capHeavy = %d, want %d", capHeavy, wantCapHeavy)
+	}
+}